@@ -0,0 +1,79 @@
+package deno
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// defaultCacheDirName is the subdirectory installs are cached under inside
+// the user's cache directory when no CacheDir override is given.
+const defaultCacheDirName = "go-space/deno"
+
+// checksumFileName records the SHA256 of a cached install's extracted Deno
+// binary, so later installs can detect a stale or tampered cache.
+const checksumFileName = ".sha256"
+
+// cacheRoot resolves the root directory installs are cached under, honoring
+// an explicit override and falling back to os.UserCacheDir()/go-space/deno.
+func cacheRoot(override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userCacheDir, defaultCacheDirName), nil
+}
+
+// platformCacheDir returns the directory a given version+platform's Deno
+// binary is cached under: <root>/<version>/<os>-<arch>/.
+func platformCacheDir(root, version, denoOS, denoArch string) string {
+	return filepath.Join(root, version, denoOS+"-"+denoArch)
+}
+
+// readCachedChecksum returns the SHA256 recorded for a cached install, if any.
+func readCachedChecksum(dir string) (string, bool) {
+	contents, err := ioutil.ReadFile(filepath.Join(dir, checksumFileName))
+	if err != nil {
+		return "", false
+	}
+	return string(contents), true
+}
+
+// writeCachedChecksum records the SHA256 of an install's extracted binary,
+// so future installs can verify the cache before reusing it.
+func writeCachedChecksum(dir, sha256Hex string) error {
+	return ioutil.WriteFile(filepath.Join(dir, checksumFileName), []byte(sha256Hex), 0644)
+}
+
+// hashFile returns the hex-encoded SHA256 of the file at path, streaming it
+// rather than reading it fully into memory.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// Purge removes all cached installs for a given Deno version across every
+// platform, under cacheDir. cacheDir should match the InstallOptions.CacheDir
+// the installs were made with; pass "" to purge the default cache directory.
+func Purge(cacheDir, version string) error {
+	root, err := cacheRoot(cacheDir)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(root, version))
+}
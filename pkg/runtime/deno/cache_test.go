@@ -0,0 +1,97 @@
+package deno
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+)
+
+func TestInstallWithOptions_CacheHitMakesNoHTTPRequest(t *testing.T) {
+	body := buildZip(t, map[string]string{"deno": "a real binary, honest"})
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	cacheDir, err := ioutil.TempDir("", "deno-cache-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	opts := InstallOptions{
+		Version:  "v1.0.0",
+		BaseURL:  server.URL,
+		CacheDir: cacheDir,
+	}
+
+	if _, err := InstallWithOptions(opts); err != nil {
+		t.Fatalf("first install failed: %s", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Fatalf("expected 1 HTTP request after first install, got %d", got)
+	}
+
+	if _, err := InstallWithOptions(opts); err != nil {
+		t.Fatalf("second install failed: %s", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Fatalf("expected cache hit to make no additional HTTP request, got %d total", got)
+	}
+}
+
+func TestInstallWithOptions_CorruptedCacheIsRedownloaded(t *testing.T) {
+	body := buildZip(t, map[string]string{"deno": "a real binary, honest"})
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	cacheDir, err := ioutil.TempDir("", "deno-cache-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	opts := InstallOptions{
+		Version:  "v1.0.0",
+		BaseURL:  server.URL,
+		CacheDir: cacheDir,
+	}
+
+	ctx, err := InstallWithOptions(opts)
+	if err != nil {
+		t.Fatalf("first install failed: %s", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Fatalf("expected 1 HTTP request after first install, got %d", got)
+	}
+
+	if err := ioutil.WriteFile(ctx.DenoPath, []byte("corrupted"), 0755); err != nil {
+		t.Fatalf("failed to corrupt cached binary: %s", err)
+	}
+
+	if _, err := InstallWithOptions(opts); err != nil {
+		t.Fatalf("install over corrupted cache failed: %s", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Fatalf("expected corrupted cache to trigger a re-download, got %d total requests", got)
+	}
+
+	contents, err := ioutil.ReadFile(ctx.DenoPath)
+	if err != nil {
+		t.Fatalf("failed to read repaired cached binary: %s", err)
+	}
+	if string(contents) == "corrupted" {
+		t.Fatal("expected corrupted cache entry to be replaced, but it was reused")
+	}
+}
@@ -0,0 +1,70 @@
+package deno
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// buildZip constructs an in-memory zip archive from name -> contents pairs,
+// for serving fake Deno releases in tests.
+func buildZip(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, contents := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create zip entry %s: %s", name, err)
+		}
+		if _, err := w.Write([]byte(contents)); err != nil {
+			t.Fatalf("failed to write zip entry %s: %s", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip writer: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestInstallWithOptions_ChecksumMismatchFailsBeforeUnzipping(t *testing.T) {
+	body := buildZip(t, map[string]string{"deno": "not a real binary"})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	destDir, err := ioutil.TempDir("", "deno-install-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(destDir)
+
+	_, err = InstallWithOptions(InstallOptions{
+		Version: "v1.0.0",
+		BaseURL: server.URL,
+		SHA256:  strings.Repeat("0", 64),
+		DestDir: destDir,
+	})
+	if err == nil {
+		t.Fatal("expected checksum mismatch error, got nil")
+	}
+	if !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch error, got: %s", err)
+	}
+
+	entries, err := ioutil.ReadDir(destDir)
+	if err != nil {
+		t.Fatalf("failed to read destDir: %s", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no files extracted on checksum mismatch, found %d", len(entries))
+	}
+}
@@ -0,0 +1,284 @@
+package deno
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/evanlouie/go-space/pkg/logger"
+	"github.com/google/go-github/v31/github"
+)
+
+// defaultBaseURL is the GitHub releases prefix Deno archives are published under.
+const defaultBaseURL = "https://github.com/denoland/deno/releases/download"
+
+type Context struct {
+	DenoPath string
+	// Version is the resolved Deno release tag that was installed, eg
+	// "v1.8.0", whether it was pinned via InstallOptions.Version or
+	// resolved from the latest GitHub release.
+	Version string
+}
+
+// InstallOptions customizes how InstallWithOptions locates, verifies, and
+// downloads the Deno archive.
+type InstallOptions struct {
+	// Version pins the Deno release tag to install, eg "v1.8.0". When empty,
+	// the latest release is resolved via the GitHub API.
+	Version string
+	// SHA256 is the expected hex-encoded checksum of the downloaded archive.
+	// When set, the download is verified before unzipping and a mismatch
+	// fails the install.
+	SHA256 string
+	// BaseURL overrides the GitHub releases prefix archives are fetched
+	// from, eg for an internal mirror. Defaults to defaultBaseURL.
+	BaseURL string
+	// HTTPClient is used for the release download. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// DestDir is the directory Deno is unzipped into. Setting this disables
+	// the cache: installs always go to this exact directory. When unset,
+	// Deno is installed into the cache under CacheDir.
+	DestDir string
+	// CacheDir is the root directory cached installs are stored under, keyed
+	// by version and platform. Defaults to os.UserCacheDir()/go-space/deno.
+	// Ignored when DestDir is set.
+	CacheDir string
+}
+
+// Install Deno locally into the default cache directory, reusing a cached install when valid
+// Modifies DenoPath to point to the Deno executable
+func Install() (ctx Context, err error) {
+	return InstallWithOptions(InstallOptions{})
+}
+
+// InstallWithOptions installs Deno according to opts, pinning the version,
+// verifying a checksum, and/or downloading from a mirror as configured.
+// Modifies DenoPath to point to the Deno executable.
+func InstallWithOptions(opts InstallOptions) (ctx Context, err error) {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	// Resolve the release tag to install
+	version := opts.Version
+	if version == "" {
+		client := github.NewClient(nil)
+		release, _, err := client.Repositories.GetLatestRelease(context.Background(), "denoland", "deno")
+		if err != nil {
+			return ctx, fmt.Errorf("failed to fetch latest Deno GitHub release: %s", err)
+		}
+		version = *release.TagName
+	} else {
+		logger.Debugf("Deno version %s pinned; skipping GitHub release lookup", version)
+	}
+
+	// Determine host OS
+	var denoOS string
+	var denoBinName string
+	switch os := runtime.GOOS; os {
+	case "darwin":
+		logger.Debug("MacOS detected")
+		denoOS = "apple-darwin"
+		denoBinName = "deno"
+	case "linux":
+		logger.Debug("Linux detected")
+		denoOS = "unknown-linux-gnu"
+		denoBinName = "deno"
+	case "windows":
+		logger.Debug("Windows detected")
+		denoOS = "pc-windows-msvc"
+		denoBinName = "deno.exe"
+	default:
+		return ctx, fmt.Errorf("unsupported OS: %s", os)
+	}
+
+	// Determine host architecture; Deno only publishes aarch64 builds for
+	// macOS and Linux, so arm64 Windows falls back to the x86_64 asset.
+	var denoArch string
+	switch arch := runtime.GOARCH; arch {
+	case "amd64":
+		denoArch = "x86_64"
+	case "arm64":
+		if denoOS == "pc-windows-msvc" {
+			logger.Debug("arm64 Windows detected; falling back to x86_64 asset")
+			denoArch = "x86_64"
+		} else {
+			logger.Debug("arm64 detected")
+			denoArch = "aarch64"
+		}
+	default:
+		return ctx, fmt.Errorf("unsupported architecture: %s", arch)
+	}
+
+	denoUri := fmt.Sprintf("%s/%s/deno-%s-%s.zip", baseURL, version, denoArch, denoOS)
+
+	////////////////////////////////////////////////////////////////////////////////
+	// Resolve destination directory, serving a cache hit without any HTTP traffic
+	////////////////////////////////////////////////////////////////////////////////
+	denoDir := opts.DestDir
+	usingCache := denoDir == ""
+	// extractDir is where the archive is unzipped. When caching, this is a
+	// staging directory beside denoDir so a killed/failed install never
+	// leaves a partial binary at the final cached path; it is renamed into
+	// place only once the install fully succeeds.
+	extractDir := denoDir
+	if usingCache {
+		cacheDir, err := cacheRoot(opts.CacheDir)
+		if err != nil {
+			return ctx, fmt.Errorf("failed to resolve Deno cache directory: %s", err)
+		}
+		denoDir = platformCacheDir(cacheDir, version, denoOS, denoArch)
+
+		// recordedChecksum is the SHA256 of the extracted binary as it stood
+		// right after the install that populated this cache entry, not the
+		// archive's checksum (opts.SHA256 only ever gates a fresh download).
+		// Rehashing the on-disk file here, instead of trusting the sidecar
+		// text alone, is what catches a cache entry that has rotted or been
+		// tampered with since.
+		denoBinPath := filepath.Join(denoDir, denoBinName)
+		if _, statErr := os.Stat(denoBinPath); statErr == nil {
+			recordedChecksum, recorded := readCachedChecksum(denoDir)
+			actualChecksum, hashErr := hashFile(denoBinPath)
+			switch {
+			case hashErr != nil:
+				logger.Debugf("Failed to hash cached Deno binary at %s; re-downloading: %s", denoBinPath, hashErr)
+			case !recorded || actualChecksum != recordedChecksum:
+				logger.Debugf("Cached Deno %s at %s is corrupted or tampered with; re-downloading", version, denoDir)
+			default:
+				logger.Infof("Using cached Deno %s from %s", version, denoDir)
+				ctx.DenoPath = denoBinPath
+				ctx.Version = version
+				return ctx, nil
+			}
+		}
+
+		extractDir = denoDir + ".tmp"
+		if err := os.RemoveAll(extractDir); err != nil {
+			return ctx, fmt.Errorf("failed to clear Deno staging directory %s: %s", extractDir, err)
+		}
+		if err := os.MkdirAll(extractDir, 0755); err != nil {
+			return ctx, fmt.Errorf("failed to create Deno staging directory %s: %s", extractDir, err)
+		}
+		// Removed unconditionally on return: a no-op once extractDir has
+		// been renamed into place on success, and cleanup on any failure.
+		defer os.RemoveAll(extractDir)
+	}
+	logger.Infof("Downloading Deno %s to %s from %s", version, denoDir, denoUri)
+
+	////////////////////////////////////////////////////////////////////////////////
+	// Download the archive to a temporary file on disk, hashing as it streams
+	////////////////////////////////////////////////////////////////////////////////
+	resp, err := httpClient.Get(denoUri)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to download Deno from %s: %s", denoUri, err)
+	}
+	defer resp.Body.Close()
+
+	archiveFile, err := ioutil.TempFile("", "deno-*.zip")
+	if err != nil {
+		return ctx, fmt.Errorf("failed to create temporary archive file: %s", err)
+	}
+	defer os.Remove(archiveFile.Name())
+	defer archiveFile.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(archiveFile, hasher), resp.Body); err != nil {
+		return ctx, fmt.Errorf("failed to download Deno archive to %s: %s", archiveFile.Name(), err)
+	}
+
+	actualSHA256 := hex.EncodeToString(hasher.Sum(nil))
+	if opts.SHA256 != "" {
+		if actualSHA256 != opts.SHA256 {
+			return ctx, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", denoUri, opts.SHA256, actualSHA256)
+		}
+		logger.Debugf("Verified checksum for %s", denoUri)
+	}
+
+	zipReader, err := zip.OpenReader(archiveFile.Name())
+	if err != nil {
+		return ctx, fmt.Errorf("failed to open %s as a zip archive: %s", archiveFile.Name(), err)
+	}
+	defer zipReader.Close()
+
+	// Stream each entry to extractDir, preserving its mode and refusing to
+	// extract outside it (Zip Slip).
+	for _, zipFile := range zipReader.File {
+		denoFilepath := filepath.Join(extractDir, zipFile.Name)
+		if !strings.HasPrefix(denoFilepath, filepath.Clean(extractDir)+string(os.PathSeparator)) {
+			return ctx, fmt.Errorf("zip entry %s escapes destination directory %s", zipFile.Name, extractDir)
+		}
+
+		mode := zipFile.Mode()
+		if mode == 0 {
+			if zipFile.Name == denoBinName {
+				mode = 0755
+			} else {
+				mode = 0644
+			}
+		}
+
+		if err := extractZipFile(zipFile, denoFilepath, mode); err != nil {
+			return ctx, fmt.Errorf("failed to extract %s to %s: %s", zipFile.Name, denoFilepath, err)
+		}
+		logger.Infof("Wrote %s to %s", zipFile.Name, denoFilepath)
+	}
+
+	if usingCache {
+		// Record the checksum of the extracted binary itself (not the
+		// archive) so a later cache hit can detect on-disk corruption, then
+		// publish the install atomically: only a fully extracted,
+		// checksummed staging directory is ever renamed into the final
+		// cache path.
+		extractedBinSHA256, err := hashFile(filepath.Join(extractDir, denoBinName))
+		if err != nil {
+			return ctx, fmt.Errorf("failed to hash extracted Deno binary at %s: %s", extractDir, err)
+		}
+		if err := writeCachedChecksum(extractDir, extractedBinSHA256); err != nil {
+			return ctx, fmt.Errorf("failed to record checksum for cached install at %s: %s", extractDir, err)
+		}
+		if err := os.RemoveAll(denoDir); err != nil {
+			return ctx, fmt.Errorf("failed to clear stale Deno cache directory %s: %s", denoDir, err)
+		}
+		if err := os.Rename(extractDir, denoDir); err != nil {
+			return ctx, fmt.Errorf("failed to publish Deno cache directory %s: %s", denoDir, err)
+		}
+	}
+
+	ctx.DenoPath = filepath.Join(denoDir, denoBinName)
+	ctx.Version = version
+
+	return ctx, nil
+}
+
+// extractZipFile streams a single zip entry to destPath with the given
+// file mode, without buffering the whole entry in memory.
+func extractZipFile(zipFile *zip.File, destPath string, mode os.FileMode) error {
+	src, err := zipFile.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(destPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	return err
+}
@@ -0,0 +1,118 @@
+package deno
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Unscoped, when the sole element of an Allow field, grants that capability
+// without restriction (the bare `--allow-X` flag) instead of scoping it to
+// a list of values.
+const Unscoped = "*"
+
+// Allow enumerates the permission grants to pass to `deno run`. A nil or
+// empty field denies that capability; a populated slice scopes it to the
+// listed values, unless that slice is exactly []string{Unscoped}, which
+// grants the capability without restriction; All grants every capability
+// via `--allow-all`, overriding the other fields.
+type Allow struct {
+	Net   []string
+	Read  []string
+	Write []string
+	Env   []string
+	Run   []string
+	FFI   []string
+	All   bool
+}
+
+// RunOptions configures a single `deno run` invocation. Deno denies every
+// permission by default, so the zero value runs fully sandboxed code with
+// no network, filesystem, or subprocess access.
+type RunOptions struct {
+	Allow Allow
+	// Env are additional environment variables passed to the Deno process,
+	// in "KEY=VALUE" form.
+	Env []string
+	// Context bounds how long the script may run; a cancelled or expired
+	// context kills the Deno process.
+	Context context.Context
+}
+
+// RunScript writes code to a temporary .ts file and executes it with RunFile.
+func (ctx *Context) RunScript(code string, opts RunOptions) (stdout string, stderr string, exitCode int, err error) {
+	dir, err := ioutil.TempDir("", "deno-script")
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to create temporary directory for script: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	scriptPath := filepath.Join(dir, "script.ts")
+	if err := ioutil.WriteFile(scriptPath, []byte(code), 0644); err != nil {
+		return "", "", 0, fmt.Errorf("failed to write script to %s: %s", scriptPath, err)
+	}
+
+	return ctx.RunFile(scriptPath, opts)
+}
+
+// RunFile executes the given TS/JS file with `deno run`, applying the
+// permission set and environment in opts and capturing stdout/stderr.
+func (ctx *Context) RunFile(path string, opts RunOptions) (stdout string, stderr string, exitCode int, err error) {
+	runCtx := opts.Context
+	if runCtx == nil {
+		runCtx = context.Background()
+	}
+
+	args := append([]string{"run"}, opts.Allow.flags()...)
+	args = append(args, path)
+
+	cmd := exec.CommandContext(runCtx, ctx.DenoPath, args...)
+	cmd.Env = append(os.Environ(), opts.Env...)
+
+	var stdoutBuf, stderrBuf bytes.Buffer
+	cmd.Stdout = &stdoutBuf
+	cmd.Stderr = &stderrBuf
+
+	runErr := cmd.Run()
+	exitCode = cmd.ProcessState.ExitCode()
+	if runErr != nil {
+		if _, ok := runErr.(*exec.ExitError); !ok {
+			err = fmt.Errorf("failed to run %s: %s", path, runErr)
+		}
+	}
+
+	return stdoutBuf.String(), stderrBuf.String(), exitCode, err
+}
+
+// flags translates Allow into `deno run` permission flags.
+func (a Allow) flags() []string {
+	if a.All {
+		return []string{"--allow-all"}
+	}
+
+	var flags []string
+	appendFlag := func(name string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		if len(values) == 1 && values[0] == Unscoped {
+			flags = append(flags, fmt.Sprintf("--allow-%s", name))
+			return
+		}
+		flags = append(flags, fmt.Sprintf("--allow-%s=%s", name, strings.Join(values, ",")))
+	}
+
+	appendFlag("net", a.Net)
+	appendFlag("read", a.Read)
+	appendFlag("write", a.Write)
+	appendFlag("env", a.Env)
+	appendFlag("run", a.Run)
+	appendFlag("ffi", a.FFI)
+
+	return flags
+}
@@ -0,0 +1,58 @@
+package deno
+
+import (
+	"context"
+
+	"github.com/evanlouie/go-space/pkg/runtime"
+)
+
+// Runtime adapts the deno package's Install/RunScript API to
+// pkg/runtime.Runtime, so callers can treat Deno as one of several
+// pluggable scripting runtimes.
+type Runtime struct {
+	// Options configures Install, eg to pin a version or point at a mirror.
+	Options InstallOptions
+
+	ctx Context
+}
+
+var _ runtime.Runtime = (*Runtime)(nil)
+
+// Install downloads and extracts Deno per r.Options.
+func (r *Runtime) Install(_ context.Context) (runtime.Context, error) {
+	ctx, err := InstallWithOptions(r.Options)
+	if err != nil {
+		return runtime.Context{}, err
+	}
+	r.ctx = ctx
+	return runtime.Context{Path: ctx.DenoPath}, nil
+}
+
+// Run executes code as a Deno script with the zero-value (fully denied)
+// permission set. Use Context().RunScript directly for finer-grained
+// permissions.
+func (r *Runtime) Run(code string, opts runtime.RunOptions) (runtime.Result, error) {
+	stdout, stderr, exitCode, err := r.ctx.RunScript(code, RunOptions{
+		Env:     opts.Env,
+		Context: opts.Context,
+	})
+	return runtime.Result{Stdout: stdout, Stderr: stderr, ExitCode: exitCode}, err
+}
+
+// Context returns the deno.Context produced by the last successful Install,
+// so callers needing a custom Allow permission set can call
+// (*Context).RunScript directly instead of going through the generalized,
+// always-denied Run.
+func (r *Runtime) Context() Context {
+	return r.ctx
+}
+
+// Name returns "deno".
+func (r *Runtime) Name() string {
+	return "deno"
+}
+
+// Version returns the Deno release installed by the last successful Install.
+func (r *Runtime) Version() string {
+	return r.ctx.Version
+}
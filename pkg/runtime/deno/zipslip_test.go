@@ -0,0 +1,42 @@
+package deno
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallWithOptions_RejectsZipSlipEntries(t *testing.T) {
+	body := buildZip(t, map[string]string{"../../evil": "malicious"})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	destDir, err := ioutil.TempDir("", "deno-install-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(destDir)
+
+	_, err = InstallWithOptions(InstallOptions{
+		Version: "v1.0.0",
+		BaseURL: server.URL,
+		DestDir: destDir,
+	})
+	if err == nil {
+		t.Fatal("expected zip entry to be rejected, got nil error")
+	}
+	if !strings.Contains(err.Error(), "escapes destination directory") {
+		t.Fatalf("expected zip slip error, got: %s", err)
+	}
+
+	if _, statErr := os.Stat(filepath.Join(destDir, "../../evil")); statErr == nil {
+		t.Fatal("zip slip entry was written outside destDir")
+	}
+}
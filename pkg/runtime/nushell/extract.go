@@ -0,0 +1,77 @@
+package nushell
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// extractTarGzBinary streams binName out of a .tar.gz archive to destPath,
+// ignoring the rest of the archive's contents.
+func extractTarGzBinary(archivePath, binName, destPath string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return fmt.Errorf("%s not found in archive", binName)
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg || filepath.Base(header.Name) != binName {
+			continue
+		}
+		return writeFile(destPath, tr, 0755)
+	}
+}
+
+// extractZipBinary streams binName out of a .zip archive to destPath,
+// ignoring the rest of the archive's contents.
+func extractZipBinary(archivePath, binName, destPath string) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, zipFile := range zr.File {
+		if filepath.Base(zipFile.Name) != binName {
+			continue
+		}
+		src, err := zipFile.Open()
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		return writeFile(destPath, src, 0755)
+	}
+
+	return fmt.Errorf("%s not found in archive", binName)
+}
+
+func writeFile(destPath string, src io.Reader, mode os.FileMode) error {
+	dst, err := os.OpenFile(destPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	return err
+}
@@ -0,0 +1,169 @@
+package nushell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/evanlouie/go-space/pkg/logger"
+	"github.com/google/go-github/v31/github"
+)
+
+// defaultBaseURL is the GitHub releases prefix Nushell archives are published under.
+const defaultBaseURL = "https://github.com/nushell/nushell/releases/download"
+
+type Context struct {
+	NuPath string
+	// Version is the resolved Nushell release tag that was installed, eg
+	// "0.93.0", whether it was pinned via InstallOptions.Version or
+	// resolved from the latest GitHub release.
+	Version string
+}
+
+// InstallOptions customizes how InstallWithOptions locates and downloads
+// the Nushell archive.
+type InstallOptions struct {
+	// Version pins the Nushell release tag to install, eg "0.93.0". When
+	// empty, the latest release is resolved via the GitHub API.
+	Version string
+	// BaseURL overrides the GitHub releases prefix archives are fetched
+	// from, eg for an internal mirror. Defaults to defaultBaseURL.
+	BaseURL string
+	// HTTPClient is used for the release download. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// DestDir is the directory the Nushell binary is extracted into.
+	// Defaults to a new temporary directory.
+	DestDir string
+}
+
+// Install Nushell locally to a temporary directory
+// Modifies NuPath to point to the Nushell executable
+func Install() (ctx Context, err error) {
+	return InstallWithOptions(InstallOptions{})
+}
+
+// InstallWithOptions installs Nushell according to opts, pinning the
+// version and/or downloading from a mirror as configured. Modifies NuPath
+// to point to the Nushell executable.
+func InstallWithOptions(opts InstallOptions) (ctx Context, err error) {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	// Resolve the release tag to install
+	version := opts.Version
+	if version == "" {
+		client := github.NewClient(nil)
+		release, _, err := client.Repositories.GetLatestRelease(context.Background(), "nushell", "nushell")
+		if err != nil {
+			return ctx, fmt.Errorf("failed to fetch latest Nushell GitHub release: %s", err)
+		}
+		version = strings.TrimPrefix(*release.TagName, "v")
+	} else {
+		logger.Debugf("Nushell version %s pinned; skipping GitHub release lookup", version)
+	}
+
+	// Determine host OS
+	var nuOS string
+	var nuBinName string
+	var archiveExt string
+	switch os := runtime.GOOS; os {
+	case "darwin":
+		logger.Debug("MacOS detected")
+		nuOS = "apple-darwin"
+		nuBinName = "nu"
+		archiveExt = "tar.gz"
+	case "linux":
+		logger.Debug("Linux detected")
+		nuOS = "unknown-linux-gnu"
+		nuBinName = "nu"
+		archiveExt = "tar.gz"
+	case "windows":
+		logger.Debug("Windows detected")
+		nuOS = "pc-windows-msvc"
+		nuBinName = "nu.exe"
+		archiveExt = "zip"
+	default:
+		return ctx, fmt.Errorf("unsupported OS: %s", os)
+	}
+
+	// Determine host architecture; Nushell only publishes aarch64 builds
+	// for macOS and Linux, so arm64 Windows falls back to the x86_64 asset.
+	var nuArch string
+	switch arch := runtime.GOARCH; arch {
+	case "amd64":
+		nuArch = "x86_64"
+	case "arm64":
+		if nuOS == "pc-windows-msvc" {
+			logger.Debug("arm64 Windows detected; falling back to x86_64 asset")
+			nuArch = "x86_64"
+		} else {
+			logger.Debug("arm64 detected")
+			nuArch = "aarch64"
+		}
+	default:
+		return ctx, fmt.Errorf("unsupported architecture: %s", arch)
+	}
+
+	// Release archives are named nu-<version>-<arch>-<os>.<ext>, eg
+	// nu-0.93.0-x86_64-unknown-linux-gnu.tar.gz
+	archiveName := fmt.Sprintf("nu-%s-%s-%s.%s", version, nuArch, nuOS, archiveExt)
+	nuUri := fmt.Sprintf("%s/%s/%s", baseURL, version, archiveName)
+
+	////////////////////////////////////////////////////////////////////////////////
+	// Download the archive to a temporary file, then extract the binary
+	////////////////////////////////////////////////////////////////////////////////
+	nuDir := opts.DestDir
+	if nuDir == "" {
+		nuDir, err = ioutil.TempDir("", "nushell")
+		if err != nil {
+			return ctx, fmt.Errorf("failed to create Nushell temporary directory %s: %s", nuDir, err)
+		}
+	}
+	logger.Infof("Downloading Nushell %s to %s from %s", version, nuDir, nuUri)
+
+	resp, err := httpClient.Get(nuUri)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to download Nushell from %s: %s", nuUri, err)
+	}
+	defer resp.Body.Close()
+
+	archiveFile, err := ioutil.TempFile("", "nushell-*."+archiveExt)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to create temporary archive file: %s", err)
+	}
+	defer os.Remove(archiveFile.Name())
+	defer archiveFile.Close()
+
+	if _, err := io.Copy(archiveFile, resp.Body); err != nil {
+		return ctx, fmt.Errorf("failed to download Nushell archive to %s: %s", archiveFile.Name(), err)
+	}
+
+	nuBinPath := filepath.Join(nuDir, nuBinName)
+	var extractErr error
+	if archiveExt == "zip" {
+		extractErr = extractZipBinary(archiveFile.Name(), nuBinName, nuBinPath)
+	} else {
+		extractErr = extractTarGzBinary(archiveFile.Name(), nuBinName, nuBinPath)
+	}
+	if extractErr != nil {
+		return ctx, fmt.Errorf("failed to extract %s from %s: %s", nuBinName, archiveFile.Name(), extractErr)
+	}
+	logger.Infof("Wrote %s to %s", nuBinName, nuBinPath)
+
+	ctx.NuPath = nuBinPath
+	ctx.Version = version
+
+	return ctx, nil
+}
@@ -0,0 +1,63 @@
+package nushell
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// RunOptions configures a single `nu` script invocation.
+type RunOptions struct {
+	// Env are additional environment variables passed to the Nushell
+	// process, in "KEY=VALUE" form.
+	Env []string
+	// Context bounds how long the script may run; a cancelled or expired
+	// context kills the Nushell process.
+	Context context.Context
+}
+
+// RunScript writes code to a temporary .nu file and executes it with RunFile.
+func (ctx *Context) RunScript(code string, opts RunOptions) (stdout string, stderr string, exitCode int, err error) {
+	dir, err := ioutil.TempDir("", "nushell-script")
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to create temporary directory for script: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	scriptPath := filepath.Join(dir, "script.nu")
+	if err := ioutil.WriteFile(scriptPath, []byte(code), 0644); err != nil {
+		return "", "", 0, fmt.Errorf("failed to write script to %s: %s", scriptPath, err)
+	}
+
+	return ctx.RunFile(scriptPath, opts)
+}
+
+// RunFile executes the given .nu file with `nu`, applying the environment
+// in opts and capturing stdout/stderr.
+func (ctx *Context) RunFile(path string, opts RunOptions) (stdout string, stderr string, exitCode int, err error) {
+	runCtx := opts.Context
+	if runCtx == nil {
+		runCtx = context.Background()
+	}
+
+	cmd := exec.CommandContext(runCtx, ctx.NuPath, path)
+	cmd.Env = append(os.Environ(), opts.Env...)
+
+	var stdoutBuf, stderrBuf bytes.Buffer
+	cmd.Stdout = &stdoutBuf
+	cmd.Stderr = &stderrBuf
+
+	runErr := cmd.Run()
+	exitCode = cmd.ProcessState.ExitCode()
+	if runErr != nil {
+		if _, ok := runErr.(*exec.ExitError); !ok {
+			err = fmt.Errorf("failed to run %s: %s", path, runErr)
+		}
+	}
+
+	return stdoutBuf.String(), stderrBuf.String(), exitCode, err
+}
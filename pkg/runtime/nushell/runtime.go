@@ -0,0 +1,48 @@
+package nushell
+
+import (
+	"context"
+
+	"github.com/evanlouie/go-space/pkg/runtime"
+)
+
+// Runtime adapts the nushell package's Install/RunScript API to
+// pkg/runtime.Runtime, so callers can treat Nushell as one of several
+// pluggable scripting runtimes.
+type Runtime struct {
+	// Options configures Install, eg to pin a version or point at a mirror.
+	Options InstallOptions
+
+	ctx Context
+}
+
+var _ runtime.Runtime = (*Runtime)(nil)
+
+// Install downloads and extracts Nushell per r.Options.
+func (r *Runtime) Install(_ context.Context) (runtime.Context, error) {
+	ctx, err := InstallWithOptions(r.Options)
+	if err != nil {
+		return runtime.Context{}, err
+	}
+	r.ctx = ctx
+	return runtime.Context{Path: ctx.NuPath}, nil
+}
+
+// Run executes code as a Nushell script.
+func (r *Runtime) Run(code string, opts runtime.RunOptions) (runtime.Result, error) {
+	stdout, stderr, exitCode, err := r.ctx.RunScript(code, RunOptions{
+		Env:     opts.Env,
+		Context: opts.Context,
+	})
+	return runtime.Result{Stdout: stdout, Stderr: stderr, ExitCode: exitCode}, err
+}
+
+// Name returns "nushell".
+func (r *Runtime) Name() string {
+	return "nushell"
+}
+
+// Version returns the Nushell release installed by the last successful Install.
+func (r *Runtime) Version() string {
+	return r.ctx.Version
+}
@@ -0,0 +1,42 @@
+// Package runtime defines a pluggable scripting host interface so callers
+// embedding go-space can let end users choose between runtimes (Deno's
+// TS/JS, Nushell's shell-like DSL, ...) without special-casing each
+// runtime's CLI flags, env setup, or archive layout.
+package runtime
+
+import "context"
+
+// Context locates an installed runtime's executable on disk.
+type Context struct {
+	Path string
+}
+
+// Result is the outcome of a single Run invocation.
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// RunOptions configures a single script execution. Env are additional
+// environment variables in "KEY=VALUE" form; Context bounds how long the
+// script may run before it is killed.
+type RunOptions struct {
+	Env     []string
+	Context context.Context
+}
+
+// Runtime is a scripting host that can install its own executable and run
+// code through it.
+type Runtime interface {
+	// Install downloads and extracts the runtime, returning a Context
+	// pointing at its executable.
+	Install(ctx context.Context) (Context, error)
+	// Run executes code through the runtime and returns its result.
+	Run(code string, opts RunOptions) (Result, error)
+	// Name is the runtime's identifier, eg "deno" or "nushell".
+	Name() string
+	// Version is the pinned or resolved release version of the runtime,
+	// populated after a successful Install.
+	Version() string
+}